@@ -0,0 +1,317 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type batchOpKind int
+
+const (
+	batchCreateStory batchOpKind = iota
+	batchUpdateStory
+	batchDeleteStory
+	batchAddTask
+	batchAddComment
+	batchAddBlocker
+)
+
+type batchOp struct {
+	kind    batchOpKind
+	storyID int
+	story   Story
+	task    Task
+	comment Comment
+	blocker Blocker
+}
+
+// defaultBatchWorkers is the worker pool size a Batch uses unless
+// WithWorkers overrides it.
+const defaultBatchWorkers = 4
+
+// Batch groups Story mutations to be issued together through Do. Do runs
+// operations concurrently across stories through a bounded worker pool,
+// but serializes the mutations queued against the same story ID to
+// avoid write conflicts.
+type Batch struct {
+	client  ProjectClient
+	workers int
+	dryRun  bool
+	logger  func(string)
+	ops     []batchOp
+}
+
+// NewBatch returns an empty Batch bound to p, with a default worker pool
+// of 4.
+func (p ProjectClient) NewBatch() *Batch {
+	return &Batch{client: p, workers: defaultBatchWorkers}
+}
+
+// WithWorkers sets the size of the worker pool Do uses to run operations
+// concurrently.
+func (b *Batch) WithWorkers(n int) *Batch {
+	b.workers = n
+	return b
+}
+
+// DryRun makes Do validate every queued operation and describe its
+// intended HTTP request to logger, instead of sending any of them. An
+// operation that fails validation (an empty story, a story ID that was
+// never set, an empty comment) is reported in its BatchOpResult.Err and
+// logged with a "SKIPPED (invalid): " prefix.
+func (b *Batch) DryRun(logger func(string)) *Batch {
+	b.dryRun = true
+	b.logger = logger
+	return b
+}
+
+// CreateStory queues a story creation.
+func (b *Batch) CreateStory(story Story) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchCreateStory, story: story})
+	return b
+}
+
+// UpdateStory queues a story update, serialized against any other
+// operation in the batch targeting story.ID.
+func (b *Batch) UpdateStory(story Story) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchUpdateStory, storyID: story.ID, story: story})
+	return b
+}
+
+// DeleteStory queues a story deletion, serialized against any other
+// operation in the batch targeting storyID.
+func (b *Batch) DeleteStory(storyID int) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchDeleteStory, storyID: storyID})
+	return b
+}
+
+// AddTask queues a task creation under storyID, serialized against any
+// other operation in the batch targeting the same story.
+func (b *Batch) AddTask(storyID int, task Task) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchAddTask, storyID: storyID, task: task})
+	return b
+}
+
+// AddComment queues a comment creation under storyID, serialized against
+// any other operation in the batch targeting the same story.
+func (b *Batch) AddComment(storyID int, comment Comment) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchAddComment, storyID: storyID, comment: comment})
+	return b
+}
+
+// AddBlocker queues a blocker creation under storyID, serialized against
+// any other operation in the batch targeting the same story.
+func (b *Batch) AddBlocker(storyID int, blocker Blocker) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchAddBlocker, storyID: storyID, blocker: blocker})
+	return b
+}
+
+// BatchOpResult is the outcome of a single Batch operation, at the same
+// index it was queued at.
+type BatchOpResult struct {
+	Story   *Story
+	Task    *Task
+	Comment *Comment
+	Blocker *Blocker
+	Err     error
+}
+
+// BatchResult is the per-operation outcome of a Batch.Do call, in the
+// order operations were queued.
+type BatchResult []BatchOpResult
+
+// BatchError wraps every per-operation error from a Batch.Do call.
+type BatchError struct {
+	errs  []error
+	total int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d batch operations failed", len(e.errs), e.total)
+}
+
+// Unwrap exposes the individual operation errors for errors.Is/As.
+func (e *BatchError) Unwrap() []error {
+	return e.errs
+}
+
+// Do runs every queued operation and returns their outcomes in queue
+// order. Operations targeting distinct stories run concurrently, up to
+// the batch's worker pool size; operations targeting the same story run
+// in queue order on a single worker. If any operation fails, the
+// returned error is a *BatchError wrapping every individual failure.
+func (b *Batch) Do(ctx context.Context) (BatchResult, error) {
+	results := make(BatchResult, len(b.ops))
+
+	if b.dryRun {
+		var errs []error
+		for i, op := range b.ops {
+			if err := validateBatchOp(op); err != nil {
+				results[i] = BatchOpResult{Err: err}
+				errs = append(errs, err)
+				b.logf("SKIPPED (invalid): %s", describeBatchOp(op))
+				continue
+			}
+			b.log(op)
+		}
+
+		if len(errs) == 0 {
+			return results, nil
+		}
+
+		return results, &BatchError{errs: errs, total: len(b.ops)}
+	}
+
+	groups := groupBatchOps(b.ops)
+
+	workers := b.workers
+	if workers <= 0 {
+		workers = defaultBatchWorkers
+	}
+
+	jobs := make(chan []int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for indices := range jobs {
+				for _, i := range indices {
+					results[i] = b.client.runBatchOp(ctx, b.ops[i])
+				}
+			}
+		}()
+	}
+
+	for _, indices := range groups {
+		jobs <- indices
+	}
+	close(jobs)
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return results, nil
+	}
+
+	return results, &BatchError{errs: errs, total: len(b.ops)}
+}
+
+// groupBatchOps partitions the indices of ops by the story they target, so
+// Do can serialize each story's operations onto a single worker while
+// running distinct stories concurrently. CreateStory ops have no story ID
+// yet, so each gets its own key, keyed negative (via the op's queue
+// position) to avoid colliding with a real story ID of 0.
+func groupBatchOps(ops []batchOp) map[int][]int {
+	groups := make(map[int][]int)
+	for i, op := range ops {
+		key := op.storyID
+		if op.kind == batchCreateStory {
+			key = -(i + 1)
+		}
+		groups[key] = append(groups[key], i)
+	}
+	return groups
+}
+
+func (p ProjectClient) runBatchOp(ctx context.Context, op batchOp) BatchOpResult {
+	switch op.kind {
+	case batchCreateStory:
+		story, err := p.CreateStoryContext(ctx, op.story)
+		return BatchOpResult{Story: &story, Err: err}
+	case batchUpdateStory:
+		story, err := p.UpdateStoryContext(ctx, op.story)
+		return BatchOpResult{Story: &story, Err: err}
+	case batchDeleteStory:
+		err := p.DeleteStoryContext(ctx, op.storyID)
+		return BatchOpResult{Err: err}
+	case batchAddTask:
+		task, err := p.CreateTaskContext(ctx, op.storyID, op.task)
+		return BatchOpResult{Task: &task, Err: err}
+	case batchAddComment:
+		comment, err := p.CreateCommentContext(ctx, op.storyID, op.comment)
+		return BatchOpResult{Comment: &comment, Err: err}
+	case batchAddBlocker:
+		blocker, err := p.CreateBlockerContext(ctx, op.storyID, op.blocker)
+		return BatchOpResult{Blocker: &blocker, Err: err}
+	default:
+		return BatchOpResult{}
+	}
+}
+
+func (b *Batch) log(op batchOp) {
+	b.logf("%s", describeBatchOp(op))
+}
+
+func (b *Batch) logf(format string, args ...interface{}) {
+	if b.logger == nil {
+		return
+	}
+	b.logger(fmt.Sprintf(format, args...))
+}
+
+// validateBatchOp rejects operations that would fail against the real
+// API regardless of network conditions: stories and comments with no
+// content, and operations targeting a story ID that was never set.
+func validateBatchOp(op batchOp) error {
+	switch op.kind {
+	case batchCreateStory:
+		if reflect.DeepEqual(op.story, Story{}) {
+			return errors.New("create story: story must not be empty")
+		}
+	case batchUpdateStory:
+		if op.story.ID <= 0 {
+			return errors.New("update story: story.ID must be set")
+		}
+	case batchDeleteStory:
+		if op.storyID <= 0 {
+			return fmt.Errorf("delete story: invalid story id %d", op.storyID)
+		}
+	case batchAddTask:
+		if op.storyID <= 0 {
+			return fmt.Errorf("add task: invalid story id %d", op.storyID)
+		}
+	case batchAddComment:
+		if op.storyID <= 0 {
+			return fmt.Errorf("add comment: invalid story id %d", op.storyID)
+		}
+		if op.comment.Text == "" {
+			return errors.New("add comment: comment text must not be empty")
+		}
+	case batchAddBlocker:
+		if op.storyID <= 0 {
+			return fmt.Errorf("add blocker: invalid story id %d", op.storyID)
+		}
+	}
+
+	return nil
+}
+
+func describeBatchOp(op batchOp) string {
+	switch op.kind {
+	case batchCreateStory:
+		return fmt.Sprintf("POST /stories %+v", op.story)
+	case batchUpdateStory:
+		return fmt.Sprintf("PUT /stories/%d %+v", op.storyID, op.story)
+	case batchDeleteStory:
+		return fmt.Sprintf("DELETE /stories/%d", op.storyID)
+	case batchAddTask:
+		return fmt.Sprintf("POST /stories/%d/tasks %+v", op.storyID, op.task)
+	case batchAddComment:
+		return fmt.Sprintf("POST /stories/%d/comments %+v", op.storyID, op.comment)
+	case batchAddBlocker:
+		return fmt.Sprintf("POST /stories/%d/blockers %+v", op.storyID, op.blocker)
+	default:
+		return ""
+	}
+}
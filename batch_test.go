@@ -0,0 +1,102 @@
+package tracker
+
+import "testing"
+
+func TestGroupBatchOpsSerializesSameStory(t *testing.T) {
+	ops := []batchOp{
+		{kind: batchUpdateStory, storyID: 1},
+		{kind: batchAddTask, storyID: 2},
+		{kind: batchAddComment, storyID: 1},
+		{kind: batchDeleteStory, storyID: 2},
+	}
+
+	groups := groupBatchOps(ops)
+
+	if got := groups[1]; len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Fatalf("groups[1] = %v, want [0 2]", got)
+	}
+	if got := groups[2]; len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Fatalf("groups[2] = %v, want [1 3]", got)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("len(groups) = %d, want 2", len(groups))
+	}
+}
+
+func TestGroupBatchOpsGivesEachCreateStoryItsOwnGroup(t *testing.T) {
+	ops := []batchOp{
+		{kind: batchCreateStory},
+		{kind: batchCreateStory},
+		{kind: batchUpdateStory, storyID: 1},
+	}
+
+	groups := groupBatchOps(ops)
+
+	if len(groups) != 3 {
+		t.Fatalf("len(groups) = %d, want 3 (two independent creates, one update)", len(groups))
+	}
+
+	for key, indices := range groups {
+		if key >= 0 {
+			continue
+		}
+		if len(indices) != 1 {
+			t.Fatalf("create-story group %d = %v, want a single index", key, indices)
+		}
+	}
+}
+
+func TestGroupBatchOpsCreateStoryNeverCollidesWithStoryZero(t *testing.T) {
+	ops := []batchOp{
+		{kind: batchCreateStory},
+		{kind: batchUpdateStory, storyID: 0},
+	}
+
+	groups := groupBatchOps(ops)
+
+	if len(groups) != 2 {
+		t.Fatalf("len(groups) = %d, want 2 (create-story group must not collide with storyID 0)", len(groups))
+	}
+}
+
+func TestValidateBatchOpRejectsEmptyCreateStory(t *testing.T) {
+	if err := validateBatchOp(batchOp{kind: batchCreateStory}); err == nil {
+		t.Fatal("expected an error for an empty Story{}")
+	}
+}
+
+func TestValidateBatchOpRejectsUnsetUpdateStoryID(t *testing.T) {
+	if err := validateBatchOp(batchOp{kind: batchUpdateStory, story: Story{}}); err == nil {
+		t.Fatal("expected an error when story.ID is unset")
+	}
+}
+
+func TestValidateBatchOpRejectsInvalidDeleteStoryID(t *testing.T) {
+	if err := validateBatchOp(batchOp{kind: batchDeleteStory, storyID: 0}); err == nil {
+		t.Fatal("expected an error for storyID 0")
+	}
+}
+
+func TestValidateBatchOpRejectsEmptyComment(t *testing.T) {
+	op := batchOp{kind: batchAddComment, storyID: 1, comment: Comment{Text: ""}}
+	if err := validateBatchOp(op); err == nil {
+		t.Fatal("expected an error for an empty comment")
+	}
+}
+
+func TestValidateBatchOpAcceptsWellFormedOps(t *testing.T) {
+	ops := []batchOp{
+		{kind: batchCreateStory, story: Story{ID: 1}},
+		{kind: batchUpdateStory, story: Story{ID: 1}},
+		{kind: batchDeleteStory, storyID: 1},
+		{kind: batchAddTask, storyID: 1, task: Task{}},
+		{kind: batchAddComment, storyID: 1, comment: Comment{Text: "hi"}},
+		{kind: batchAddBlocker, storyID: 1, blocker: Blocker{}},
+	}
+
+	for _, op := range ops {
+		if err := validateBatchOp(op); err != nil {
+			t.Fatalf("validateBatchOp(%+v) = %v, want nil", op, err)
+		}
+	}
+}
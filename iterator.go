@@ -0,0 +1,540 @@
+package tracker
+
+import "context"
+
+// DefaultPageSize is the page size iterators use when the caller's query
+// does not set one, matching Tracker's maximum page size.
+const DefaultPageSize = 500
+
+// totalExhausted reports whether a Total-tracking iterator (StoryIterator,
+// IterationIterator, StoryActivityIterator) has fetched every entry, given
+// how many it has fetched so far and the Pagination.Total of the most
+// recent page. hasFetchedAPage distinguishes "haven't fetched yet" from
+// "fetched all 0 of a 0-length result", which both leave fetched == total
+// == 0.
+func totalExhausted(hasFetchedAPage bool, fetched, total int) bool {
+	return hasFetchedAPage && fetched >= total
+}
+
+// StoryIterator walks every Story matching a StoriesQuery, transparently
+// reissuing requests with an advancing Offset as each page is exhausted.
+type StoryIterator struct {
+	ctx     context.Context
+	client  ProjectClient
+	query   StoriesQuery
+	buffer  []Story
+	index   int
+	fetched int
+	total   int
+	done    bool
+	err     error
+}
+
+// IterateStories returns a StoryIterator over every story matching query.
+// If query.Limit is unset, pages of DefaultPageSize are used.
+func (p ProjectClient) IterateStories(ctx context.Context, query StoriesQuery) *StoryIterator {
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+
+	return &StoryIterator{ctx: ctx, client: p, query: query, index: -1}
+}
+
+// Next advances to the next story, fetching another page once Pagination.Total
+// says more remain. It returns false once every story has been visited
+// or a request fails; check Err to tell the two apart.
+func (it *StoryIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.buffer) {
+		return true
+	}
+
+	if totalExhausted(it.buffer != nil, it.fetched, it.total) {
+		it.done = true
+		return false
+	}
+
+	query := it.query
+	query.Offset = it.fetched
+
+	stories, pagination, err := it.client.StoriesContext(it.ctx, query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.buffer = stories
+	it.index = 0
+	it.fetched += len(stories)
+	it.total = pagination.Total
+
+	if len(stories) == 0 {
+		it.done = true
+		return false
+	}
+
+	return true
+}
+
+// Story returns the story at the iterator's current position. It is only
+// valid after a call to Next has returned true.
+func (it *StoryIterator) Story() Story {
+	return it.buffer[it.index]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *StoryIterator) Err() error {
+	return it.err
+}
+
+// ForEach visits every story in order, stopping early if fn returns an
+// error. It returns that error, or any error encountered while paging.
+func (it *StoryIterator) ForEach(fn func(Story) error) error {
+	for it.Next() {
+		if err := fn(it.Story()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// IterationIterator walks every Iteration matching an IterationsQuery,
+// transparently reissuing requests with an advancing Offset as each page
+// is exhausted.
+type IterationIterator struct {
+	ctx     context.Context
+	client  ProjectClient
+	query   IterationsQuery
+	buffer  []Iteration
+	index   int
+	fetched int
+	total   int
+	done    bool
+	err     error
+}
+
+// IterateIterations returns an IterationIterator over every iteration
+// matching query. If query.Limit is unset, pages of DefaultPageSize are
+// used.
+func (p ProjectClient) IterateIterations(ctx context.Context, query IterationsQuery) *IterationIterator {
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+
+	return &IterationIterator{ctx: ctx, client: p, query: query, index: -1}
+}
+
+// Next advances to the next iteration, fetching another page once
+// Pagination.Total says more remain. It returns false once every
+// iteration has been visited or a request fails; check Err to tell the
+// two apart.
+func (it *IterationIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.buffer) {
+		return true
+	}
+
+	if totalExhausted(it.buffer != nil, it.fetched, it.total) {
+		it.done = true
+		return false
+	}
+
+	query := it.query
+	query.Offset = it.fetched
+
+	iterations, pagination, err := it.client.IterationsContext(it.ctx, query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.buffer = iterations
+	it.index = 0
+	it.fetched += len(iterations)
+	it.total = pagination.Total
+
+	if len(iterations) == 0 {
+		it.done = true
+		return false
+	}
+
+	return true
+}
+
+// Iteration returns the iteration at the iterator's current position. It
+// is only valid after a call to Next has returned true.
+func (it *IterationIterator) Iteration() Iteration {
+	return it.buffer[it.index]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *IterationIterator) Err() error {
+	return it.err
+}
+
+// ForEach visits every iteration in order, stopping early if fn returns an
+// error. It returns that error, or any error encountered while paging.
+func (it *IterationIterator) ForEach(fn func(Iteration) error) error {
+	for it.Next() {
+		if err := fn(it.Iteration()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// StoryActivityIterator walks every Activity for a single story,
+// transparently reissuing requests with an advancing Offset as each page
+// is exhausted.
+type StoryActivityIterator struct {
+	ctx     context.Context
+	client  ProjectClient
+	storyId int
+	query   ActivityQuery
+	buffer  []Activity
+	index   int
+	fetched int
+	total   int
+	done    bool
+	err     error
+}
+
+// IterateStoryActivity returns a StoryActivityIterator over every activity
+// entry for storyId matching query. If query.Limit is unset, pages of
+// DefaultPageSize are used.
+func (p ProjectClient) IterateStoryActivity(ctx context.Context, storyId int, query ActivityQuery) *StoryActivityIterator {
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+
+	return &StoryActivityIterator{ctx: ctx, client: p, storyId: storyId, query: query, index: -1}
+}
+
+// Next advances to the next activity entry, fetching another page once
+// Pagination.Total says more remain. It returns false once every entry
+// has been visited or a request fails; check Err to tell the two apart.
+func (it *StoryActivityIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.buffer) {
+		return true
+	}
+
+	if totalExhausted(it.buffer != nil, it.fetched, it.total) {
+		it.done = true
+		return false
+	}
+
+	query := it.query
+	query.Offset = it.fetched
+
+	activities, response, err := it.client.StoryActivityEx(it.ctx, it.storyId, query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.buffer = activities
+	it.index = 0
+	it.fetched += len(activities)
+	it.total = response.Pagination.Total
+
+	if len(activities) == 0 {
+		it.done = true
+		return false
+	}
+
+	return true
+}
+
+// Activity returns the activity entry at the iterator's current position.
+// It is only valid after a call to Next has returned true.
+func (it *StoryActivityIterator) Activity() Activity {
+	return it.buffer[it.index]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *StoryActivityIterator) Err() error {
+	return it.err
+}
+
+// ForEach visits every activity entry in order, stopping early if fn
+// returns an error. It returns that error, or any error encountered while
+// paging.
+func (it *StoryActivityIterator) ForEach(fn func(Activity) error) error {
+	for it.Next() {
+		if err := fn(it.Activity()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// TaskIterator walks every Task for a single story, transparently
+// reissuing requests with an advancing Offset as each page is exhausted.
+// Unlike StoryIterator and its siblings, the tasks endpoint doesn't return
+// a Pagination.Total, so the iterator instead stops once a page comes back
+// shorter than the requested limit.
+type TaskIterator struct {
+	ctx     context.Context
+	client  ProjectClient
+	storyId int
+	query   TaskQuery
+	buffer  []Task
+	index   int
+	fetched int
+	done    bool
+	err     error
+}
+
+// IterateStoryTasks returns a TaskIterator over every task for storyId
+// matching query. If query.Limit is unset, pages of DefaultPageSize are
+// used.
+func (p ProjectClient) IterateStoryTasks(ctx context.Context, storyId int, query TaskQuery) *TaskIterator {
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+
+	return &TaskIterator{ctx: ctx, client: p, storyId: storyId, query: query, index: -1}
+}
+
+// Next advances to the next task, fetching another page until one comes
+// back shorter than the requested limit (the tasks endpoint has no
+// Pagination.Total to check instead). It returns false
+// once every task has been visited or a request fails; check Err to tell
+// the two apart.
+func (it *TaskIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.buffer) {
+		return true
+	}
+
+	query := it.query
+	query.Offset = it.fetched
+
+	tasks, err := it.client.StoryTasksContext(it.ctx, it.storyId, query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.buffer = tasks
+	it.index = 0
+	it.fetched += len(tasks)
+	it.done = len(tasks) < query.Limit
+
+	return len(tasks) > 0
+}
+
+// Task returns the task at the iterator's current position. It is only
+// valid after a call to Next has returned true.
+func (it *TaskIterator) Task() Task {
+	return it.buffer[it.index]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *TaskIterator) Err() error {
+	return it.err
+}
+
+// ForEach visits every task in order, stopping early if fn returns an
+// error. It returns that error, or any error encountered while paging.
+func (it *TaskIterator) ForEach(fn func(Task) error) error {
+	for it.Next() {
+		if err := fn(it.Task()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// CommentIterator walks every Comment for a single story, transparently
+// reissuing requests with an advancing Offset as each page is exhausted.
+// Like TaskIterator, and unlike StoryIterator and its siblings, the
+// comments endpoint doesn't return a Pagination.Total, so the iterator
+// stops once a page comes back empty.
+type CommentIterator struct {
+	ctx     context.Context
+	client  ProjectClient
+	storyId int
+	query   CommentsQuery
+	buffer  []Comment
+	index   int
+	fetched int
+	done    bool
+	err     error
+}
+
+// IterateStoryComments returns a CommentIterator over every comment for
+// storyId matching query. If query.Limit is unset, pages of
+// DefaultPageSize are used.
+func (p ProjectClient) IterateStoryComments(ctx context.Context, storyId int, query CommentsQuery) *CommentIterator {
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+
+	return &CommentIterator{ctx: ctx, client: p, storyId: storyId, query: query, index: -1}
+}
+
+// Next advances to the next comment, fetching another page until one
+// comes back empty (the comments endpoint, like tasks, has no
+// Pagination.Total to check instead). It returns false once every comment
+// has been visited or a request fails; check Err to tell the two apart.
+func (it *CommentIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.buffer) {
+		return true
+	}
+
+	query := it.query
+	query.Offset = it.fetched
+
+	comments, err := it.client.StoryCommentsContext(it.ctx, it.storyId, query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.buffer = comments
+	it.index = 0
+	it.fetched += len(comments)
+	it.done = len(comments) == 0
+
+	return len(comments) > 0
+}
+
+// Comment returns the comment at the iterator's current position. It is
+// only valid after a call to Next has returned true.
+func (it *CommentIterator) Comment() Comment {
+	return it.buffer[it.index]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *CommentIterator) Err() error {
+	return it.err
+}
+
+// ForEach visits every comment in order, stopping early if fn returns an
+// error. It returns that error, or any error encountered while paging.
+func (it *CommentIterator) ForEach(fn func(Comment) error) error {
+	for it.Next() {
+		if err := fn(it.Comment()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// MembershipIterator walks every ProjectMembership for a project.
+// Tracker does not paginate the memberships endpoint, so the iterator
+// fetches a single page up front; it exists for API symmetry with the
+// other Iterate* helpers.
+type MembershipIterator struct {
+	ctx     context.Context
+	client  ProjectClient
+	buffer  []ProjectMembership
+	index   int
+	fetched bool
+	err     error
+}
+
+// IterateMemberships returns a MembershipIterator over every membership
+// of the project.
+func (p ProjectClient) IterateMemberships(ctx context.Context) *MembershipIterator {
+	return &MembershipIterator{ctx: ctx, client: p, index: -1}
+}
+
+// Next advances the iterator, fetching the memberships on first use; the
+// memberships endpoint isn't paginated, so no later call issues another
+// request.
+func (it *MembershipIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	if !it.fetched {
+		memberships, err := it.client.ProjectMembershipsContext(it.ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.buffer = memberships
+		it.fetched = true
+	}
+
+	it.index++
+	return it.index < len(it.buffer)
+}
+
+// Membership returns the membership at the iterator's current position.
+// It is only valid after a call to Next has returned true.
+func (it *MembershipIterator) Membership() ProjectMembership {
+	return it.buffer[it.index]
+}
+
+// Err returns the first error encountered while fetching, if any.
+func (it *MembershipIterator) Err() error {
+	return it.err
+}
+
+// ForEach visits every membership in order, stopping early if fn returns
+// an error. It returns that error, or any error encountered while
+// fetching.
+func (it *MembershipIterator) ForEach(fn func(ProjectMembership) error) error {
+	for it.Next() {
+		if err := fn(it.Membership()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,40 @@
+package tracker
+
+import "testing"
+
+// TestTotalExhaustedSurvivesAnETagCacheHit covers the iterator side of an
+// ETag cache hit: doEx restores the cached Pagination on a 304 (see
+// response.go's cachedResponse), so the Iterate* helpers see the real
+// Total, and this test checks that totalExhausted, given that Total,
+// only fires once every entry has actually been fetched. It calls
+// totalExhausted directly; the restoring of Pagination.Total itself is
+// checked by TestCachedResponsePreservesPaginationTotalAcrossA304.
+func TestTotalExhaustedSurvivesAnETagCacheHit(t *testing.T) {
+	const realTotal = 3
+
+	fetched := 0
+	hasFetchedAPage := false
+	for entry := 0; entry < realTotal; entry++ {
+		if totalExhausted(hasFetchedAPage, fetched, realTotal) {
+			t.Fatalf("totalExhausted reported done after %d of %d entries fetched", fetched, realTotal)
+		}
+		fetched++
+		hasFetchedAPage = true
+	}
+
+	if !totalExhausted(hasFetchedAPage, fetched, realTotal) {
+		t.Fatalf("totalExhausted did not report done after fetching all %d entries", realTotal)
+	}
+}
+
+func TestTotalExhaustedWaitsForFirstPage(t *testing.T) {
+	if totalExhausted(false, 0, 0) {
+		t.Fatal("totalExhausted reported done before any page was fetched")
+	}
+}
+
+func TestTotalExhaustedHandlesEmptyResult(t *testing.T) {
+	if !totalExhausted(true, 0, 0) {
+		t.Fatal("totalExhausted did not report done for a genuinely empty result")
+	}
+}
@@ -2,6 +2,7 @@ package tracker
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,8 +24,28 @@ const (
 )
 
 type ProjectClient struct {
-	id   int
-	conn connection
+	id          int
+	conn        connection
+	etagCache   ETagCache
+	retryPolicy RetryPolicy
+}
+
+// TimeoutProjectClient wraps a ProjectClient and derives a fresh
+// context.Context with a fixed timeout for every call, the same way a
+// net.Conn deadline bounds a single read or write.
+type TimeoutProjectClient struct {
+	client  ProjectClient
+	timeout time.Duration
+}
+
+// WithTimeout returns a TimeoutProjectClient that applies d as a per-call
+// deadline to every method it exposes.
+func (p ProjectClient) WithTimeout(d time.Duration) TimeoutProjectClient {
+	return TimeoutProjectClient{client: p, timeout: d}
+}
+
+func (p TimeoutProjectClient) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), p.timeout)
 }
 
 type Iteration struct {
@@ -77,13 +98,18 @@ func (query IterationsQuery) Query() url.Values {
 
 
 func (p ProjectClient) Iterations(query IterationsQuery) ([]Iteration, Pagination, error) {
+	return p.IterationsContext(context.Background(), query)
+}
+
+func (p ProjectClient) IterationsContext(ctx context.Context, query IterationsQuery) ([]Iteration, Pagination, error) {
 	request, err := p.createRequest("GET", "/iterations", query.Query())
 	if err != nil {
 		return nil, Pagination{}, err
 	}
+	request = request.WithContext(ctx)
 
 	var iterations []Iteration
-	pagination, err := p.conn.Do(request, &iterations)
+	pagination, err := p.do(request, &iterations)
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -91,14 +117,44 @@ func (p ProjectClient) Iterations(query IterationsQuery) ([]Iteration, Paginatio
 	return iterations, pagination, err
 }
 
+func (p TimeoutProjectClient) Iterations(query IterationsQuery) ([]Iteration, Pagination, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.IterationsContext(ctx, query)
+}
+
+// IterationsEx behaves like Iterations but also returns the response
+// metadata (pagination, rate-limit and caching headers), and consults
+// the installed ETagCache for conditional requests.
+func (p ProjectClient) IterationsEx(ctx context.Context, query IterationsQuery) ([]Iteration, *Response, error) {
+	request, err := p.createRequest("GET", "/iterations", query.Query())
+	if err != nil {
+		return nil, nil, err
+	}
+	request = request.WithContext(ctx)
+
+	var iterations []Iteration
+	response, err := p.doEx(request, &iterations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterations, response, nil
+}
+
 func (p ProjectClient) Stories(query StoriesQuery) ([]Story, Pagination, error) {
+	return p.StoriesContext(context.Background(), query)
+}
+
+func (p ProjectClient) StoriesContext(ctx context.Context, query StoriesQuery) ([]Story, Pagination, error) {
 	request, err := p.createRequest("GET", "/stories", query.Query())
 	if err != nil {
 		return nil, Pagination{}, err
 	}
+	request = request.WithContext(ctx)
 
 	var stories []Story
-	pagination, err := p.conn.Do(request, &stories)
+	pagination, err := p.do(request, &stories)
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -106,44 +162,121 @@ func (p ProjectClient) Stories(query StoriesQuery) ([]Story, Pagination, error)
 	return stories, pagination, err
 }
 
+func (p TimeoutProjectClient) Stories(query StoriesQuery) ([]Story, Pagination, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.StoriesContext(ctx, query)
+}
+
+// StoriesEx behaves like Stories but also returns the response metadata
+// (pagination, rate-limit and caching headers), and consults the
+// installed ETagCache for conditional requests.
+func (p ProjectClient) StoriesEx(ctx context.Context, query StoriesQuery) ([]Story, *Response, error) {
+	request, err := p.createRequest("GET", "/stories", query.Query())
+	if err != nil {
+		return nil, nil, err
+	}
+	request = request.WithContext(ctx)
+
+	var stories []Story
+	response, err := p.doEx(request, &stories)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stories, response, nil
+}
+
 func (p ProjectClient) StoryActivity(storyId int, query ActivityQuery) (activities []Activity, err error) {
+	return p.StoryActivityContext(context.Background(), storyId, query)
+}
+
+func (p ProjectClient) StoryActivityContext(ctx context.Context, storyId int, query ActivityQuery) (activities []Activity, err error) {
 	url := fmt.Sprintf("/stories/%d/activity", storyId)
 
 	request, err := p.createRequest("GET", url, query.Query())
 	if err != nil {
 		return activities, err
 	}
+	request = request.WithContext(ctx)
 
-	_, err = p.conn.Do(request, &activities)
+	_, err = p.do(request, &activities)
 	return activities, err
 }
 
+func (p TimeoutProjectClient) StoryActivity(storyId int, query ActivityQuery) (activities []Activity, err error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.StoryActivityContext(ctx, storyId, query)
+}
+
+// StoryActivityEx behaves like StoryActivity but also returns the
+// response metadata.
+func (p ProjectClient) StoryActivityEx(ctx context.Context, storyId int, query ActivityQuery) (activities []Activity, response *Response, err error) {
+	url := fmt.Sprintf("/stories/%d/activity", storyId)
+
+	request, err := p.createRequest("GET", url, query.Query())
+	if err != nil {
+		return activities, nil, err
+	}
+	request = request.WithContext(ctx)
+
+	response, err = p.doEx(request, &activities)
+	return activities, response, err
+}
+
 func (p ProjectClient) StoryTasks(storyId int, query TaskQuery) (tasks []Task, err error) {
+	return p.StoryTasksContext(context.Background(), storyId, query)
+}
+
+func (p ProjectClient) StoryTasksContext(ctx context.Context, storyId int, query TaskQuery) (tasks []Task, err error) {
 	url := fmt.Sprintf("/stories/%d/tasks", storyId)
 
 	request, err := p.createRequest("GET", url, query.Query())
 	if err != nil {
 		return tasks, err
 	}
+	request = request.WithContext(ctx)
 
-	_, err = p.conn.Do(request, &tasks)
+	_, err = p.do(request, &tasks)
 	return tasks, err
 }
 
+func (p TimeoutProjectClient) StoryTasks(storyId int, query TaskQuery) (tasks []Task, err error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.StoryTasksContext(ctx, storyId, query)
+}
+
 func (p ProjectClient) StoryComments(storyId int, query CommentsQuery) (comments []Comment, err error) {
+	return p.StoryCommentsContext(context.Background(), storyId, query)
+}
+
+func (p ProjectClient) StoryCommentsContext(ctx context.Context, storyId int, query CommentsQuery) (comments []Comment, err error) {
 	url := fmt.Sprintf("/stories/%d/comments", storyId)
 
 	request, err := p.createRequest("GET", url, query.Query())
 	if err != nil {
 		return comments, err
 	}
+	request = request.WithContext(ctx)
 
-	_, err = p.conn.Do(request, &comments)
+	_, err = p.do(request, &comments)
 	return comments, err
 }
 
+func (p TimeoutProjectClient) StoryComments(storyId int, query CommentsQuery) (comments []Comment, err error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.StoryCommentsContext(ctx, storyId, query)
+}
+
 func (p ProjectClient) DeliverStoryWithComment(storyId int, comment string) error {
-	err := p.DeliverStory(storyId)
+	return p.DeliverStoryWithCommentContext(context.Background(), storyId, comment)
+}
+
+func (p ProjectClient) DeliverStoryWithCommentContext(ctx context.Context, storyId int, comment string) error {
+	err := p.DeliverStoryContext(ctx, storyId)
 	if err != nil {
 		return err
 	}
@@ -153,6 +286,7 @@ func (p ProjectClient) DeliverStoryWithComment(storyId int, comment string) erro
 	if err != nil {
 		return err
 	}
+	request = request.WithContext(ctx)
 
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(Comment{
@@ -161,28 +295,50 @@ func (p ProjectClient) DeliverStoryWithComment(storyId int, comment string) erro
 
 	p.addJSONBodyReader(request, buffer)
 
-	_, err = p.conn.Do(request, nil)
+	_, err = p.do(request, nil)
 	return err
 }
 
+func (p TimeoutProjectClient) DeliverStoryWithComment(storyId int, comment string) error {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.DeliverStoryWithCommentContext(ctx, storyId, comment)
+}
+
 func (p ProjectClient) DeliverStory(storyId int) error {
+	return p.DeliverStoryContext(context.Background(), storyId)
+}
+
+func (p ProjectClient) DeliverStoryContext(ctx context.Context, storyId int) error {
 	url := fmt.Sprintf("/stories/%d", storyId)
 	request, err := p.createRequest("PUT", url, nil)
 	if err != nil {
 		return err
 	}
+	request = request.WithContext(ctx)
 
 	p.addJSONBody(request, `{"current_state":"delivered"}`)
 
-	_, err = p.conn.Do(request, nil)
+	_, err = p.do(request, nil)
 	return err
 }
 
+func (p TimeoutProjectClient) DeliverStory(storyId int) error {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.DeliverStoryContext(ctx, storyId)
+}
+
 func (p ProjectClient) CreateStory(story Story) (Story, error) {
+	return p.CreateStoryContext(context.Background(), story)
+}
+
+func (p ProjectClient) CreateStoryContext(ctx context.Context, story Story) (Story, error) {
 	request, err := p.createRequest("POST", "/stories", nil)
 	if err != nil {
 		return Story{}, err
 	}
+	request = request.WithContext(ctx)
 
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(story)
@@ -190,16 +346,27 @@ func (p ProjectClient) CreateStory(story Story) (Story, error) {
 	p.addJSONBodyReader(request, buffer)
 
 	var createdStory Story
-	_, err = p.conn.Do(request, &createdStory)
+	_, err = p.do(request, &createdStory)
 	return createdStory, err
 }
 
+func (p TimeoutProjectClient) CreateStory(story Story) (Story, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.CreateStoryContext(ctx, story)
+}
+
 func (p ProjectClient) UpdateStory(story Story) (Story, error) {
+	return p.UpdateStoryContext(context.Background(), story)
+}
+
+func (p ProjectClient) UpdateStoryContext(ctx context.Context, story Story) (Story, error) {
 	url := fmt.Sprintf("/stories/%d", story.ID)
 	request, err := p.createRequest("PUT", url, nil)
 	if err != nil {
 		return Story{}, err
 	}
+	request = request.WithContext(ctx)
 
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(story)
@@ -207,27 +374,49 @@ func (p ProjectClient) UpdateStory(story Story) (Story, error) {
 	p.addJSONBodyReader(request, buffer)
 
 	var updatedStory Story
-	_, err = p.conn.Do(request, &updatedStory)
-	return updatedStory, nil
+	_, err = p.do(request, &updatedStory)
+	return updatedStory, err
+}
+
+func (p TimeoutProjectClient) UpdateStory(story Story) (Story, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.UpdateStoryContext(ctx, story)
 }
 
 func (p ProjectClient) DeleteStory(storyId int) error {
+	return p.DeleteStoryContext(context.Background(), storyId)
+}
+
+func (p ProjectClient) DeleteStoryContext(ctx context.Context, storyId int) error {
 	url := fmt.Sprintf("/stories/%d", storyId)
 	request, err := p.createRequest("DELETE", url, nil)
 	if err != nil {
 		return err
 	}
+	request = request.WithContext(ctx)
 
-	_, err = p.conn.Do(request, nil)
+	_, err = p.do(request, nil)
 	return err
 }
 
+func (p TimeoutProjectClient) DeleteStory(storyId int) error {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.DeleteStoryContext(ctx, storyId)
+}
+
 func (p ProjectClient) CreateTask(storyID int, task Task) (Task, error) {
+	return p.CreateTaskContext(context.Background(), storyID, task)
+}
+
+func (p ProjectClient) CreateTaskContext(ctx context.Context, storyID int, task Task) (Task, error) {
 	url := fmt.Sprintf("/stories/%d/tasks", storyID)
 	request, err := p.createRequest("POST", url, nil)
 	if err != nil {
 		return Task{}, err
 	}
+	request = request.WithContext(ctx)
 
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(task)
@@ -235,16 +424,27 @@ func (p ProjectClient) CreateTask(storyID int, task Task) (Task, error) {
 	p.addJSONBodyReader(request, buffer)
 
 	var createdTask Task
-	_, err = p.conn.Do(request, &createdTask)
+	_, err = p.do(request, &createdTask)
 	return createdTask, err
 }
 
+func (p TimeoutProjectClient) CreateTask(storyID int, task Task) (Task, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.CreateTaskContext(ctx, storyID, task)
+}
+
 func (p ProjectClient) CreateComment(storyID int, comment Comment) (Comment, error) {
+	return p.CreateCommentContext(context.Background(), storyID, comment)
+}
+
+func (p ProjectClient) CreateCommentContext(ctx context.Context, storyID int, comment Comment) (Comment, error) {
 	url := fmt.Sprintf("/stories/%d/comments", storyID)
 	request, err := p.createRequest("POST", url, nil)
 	if err != nil {
 		return Comment{}, err
 	}
+	request = request.WithContext(ctx)
 
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(comment)
@@ -252,16 +452,27 @@ func (p ProjectClient) CreateComment(storyID int, comment Comment) (Comment, err
 	p.addJSONBodyReader(request, buffer)
 
 	var createdComment Comment
-	_, err = p.conn.Do(request, &createdComment)
+	_, err = p.do(request, &createdComment)
 	return createdComment, err
 }
 
+func (p TimeoutProjectClient) CreateComment(storyID int, comment Comment) (Comment, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.CreateCommentContext(ctx, storyID, comment)
+}
+
 func (p ProjectClient) CreateBlocker(storyID int, blocker Blocker) (Blocker, error) {
+	return p.CreateBlockerContext(context.Background(), storyID, blocker)
+}
+
+func (p ProjectClient) CreateBlockerContext(ctx context.Context, storyID int, blocker Blocker) (Blocker, error) {
 	url := fmt.Sprintf("/stories/%d/blockers", storyID)
 	request, err := p.createRequest("POST", url, nil)
 	if err != nil {
 		return Blocker{}, err
 	}
+	request = request.WithContext(ctx)
 
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(blocker)
@@ -269,18 +480,29 @@ func (p ProjectClient) CreateBlocker(storyID int, blocker Blocker) (Blocker, err
 	p.addJSONBodyReader(request, buffer)
 
 	var createdBlocker Blocker
-	_, err = p.conn.Do(request, &createdBlocker)
+	_, err = p.do(request, &createdBlocker)
 	return createdBlocker, err
 }
 
+func (p TimeoutProjectClient) CreateBlocker(storyID int, blocker Blocker) (Blocker, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.CreateBlockerContext(ctx, storyID, blocker)
+}
+
 func (p ProjectClient) ProjectMemberships() ([]ProjectMembership, error) {
+	return p.ProjectMembershipsContext(context.Background())
+}
+
+func (p ProjectClient) ProjectMembershipsContext(ctx context.Context) ([]ProjectMembership, error) {
 	request, err := p.createRequest("GET", "/memberships", nil)
 	if err != nil {
 		return []ProjectMembership{}, err
 	}
+	request = request.WithContext(ctx)
 
 	var memberships []ProjectMembership
-	_, err = p.conn.Do(request, &memberships)
+	_, err = p.do(request, &memberships)
 	if err != nil {
 		return []ProjectMembership{}, err
 	}
@@ -288,14 +510,56 @@ func (p ProjectClient) ProjectMemberships() ([]ProjectMembership, error) {
 	return memberships, nil
 }
 
+func (p TimeoutProjectClient) ProjectMemberships() ([]ProjectMembership, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+	return p.client.ProjectMembershipsContext(ctx)
+}
+
+// ProjectMembershipsEx behaves like ProjectMemberships but also returns
+// the response metadata.
+func (p ProjectClient) ProjectMembershipsEx(ctx context.Context) ([]ProjectMembership, *Response, error) {
+	request, err := p.createRequest("GET", "/memberships", nil)
+	if err != nil {
+		return []ProjectMembership{}, nil, err
+	}
+	request = request.WithContext(ctx)
+
+	var memberships []ProjectMembership
+	response, err := p.doEx(request, &memberships)
+	if err != nil {
+		return []ProjectMembership{}, nil, err
+	}
+
+	return memberships, response, nil
+}
+
 func (p ProjectClient) createRequest(method string, path string, params url.Values) (*http.Request, error) {
 	projectPath := fmt.Sprintf("/projects/%d%s", p.id, path)
 	return p.conn.CreateRequest(method, projectPath, params)
 }
 
+// addJSONBodyReader buffers body and attaches it to request along with a
+// GetBody func, so the body can be replayed if the request is retried.
+// In practice only PUT requests are retried (see idempotentMethods in
+// retry.go); POST bodies are buffered the same way for consistency, but
+// doWithRetry never rereads them, since POST is deliberately excluded
+// from retries — Tracker has no way to tell a retried create from a
+// duplicate one.
 func (p ProjectClient) addJSONBodyReader(request *http.Request, body io.Reader) {
 	request.Header.Add("Content-Type", "application/json")
-	request.Body = ioutil.NopCloser(body)
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		request.Body = ioutil.NopCloser(body)
+		return
+	}
+
+	request.ContentLength = int64(len(data))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
+	request.Body, _ = request.GetBody()
 }
 
 func (p ProjectClient) addJSONBody(request *http.Request, body string) {
@@ -0,0 +1,147 @@
+package tracker
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Response carries the Tracker API metadata that accompanies every
+// request alongside the decoded payload: pagination, rate-limit
+// accounting, and the caching headers needed to make conditional
+// requests.
+type Response struct {
+	StatusCode    int
+	Header        http.Header
+	RequestID     string
+	ETag          string
+	ServerVersion string
+	Pagination    Pagination
+	RateLimit     RateLimit
+}
+
+// RateLimit reports Tracker's per-token request budget as of the most
+// recently received response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ETagCache stores the most recently seen ETag and decoded response body
+// for a cache key, so a GET that comes back 304 Not Modified can be
+// satisfied without re-fetching or re-decoding the payload. Callers
+// typically key entries on method and URL. payload is opaque to the
+// cache: it holds both the decoded body and the response metadata
+// (pagination, rate limit) that accompanied the original 200, since
+// Tracker isn't required to repeat those headers on a 304.
+type ETagCache interface {
+	Get(key string) (etag string, payload []byte, ok bool)
+	Set(key string, etag string, payload []byte)
+}
+
+// cachedResponse is what doEx actually stores in an ETagCache: the
+// decoded body plus the metadata that came with it, so a 304 hit can
+// restore both instead of leaving Pagination/RateLimit at whatever the
+// (near-empty) 304 response carried.
+type cachedResponse struct {
+	Body       json.RawMessage
+	Pagination Pagination
+	RateLimit  RateLimit
+}
+
+// SetETagCache installs cache as the ETagCache used for conditional GET
+// requests. Every GET goes through doEx, so this covers both the *Ex
+// methods (e.g. IterationsEx, StoriesEx) and their plain counterparts.
+// Passing nil disables conditional requests.
+func (p *ProjectClient) SetETagCache(cache ETagCache) {
+	p.etagCache = cache
+}
+
+func cacheKeyFor(request *http.Request) string {
+	return request.Method + " " + request.URL.String()
+}
+
+// doEx issues request through the connection, retrying it per the
+// client's RetryPolicy and decoding the body into target, and returns the
+// response metadata. When an ETagCache is installed, GET requests attach
+// If-None-Match and, on a 304 response, reuse the cached body and
+// metadata (Pagination, RateLimit) instead of the (empty) 304's own.
+// Writes are never looked up or stored in the cache, since method+URL
+// alone can't distinguish one write's body (and result) from another's.
+func (p ProjectClient) doEx(request *http.Request, target interface{}) (*Response, error) {
+	useCache := p.etagCache != nil && request.Method == http.MethodGet
+
+	var cacheKey string
+	if useCache {
+		cacheKey = cacheKeyFor(request)
+		if etag, _, ok := p.etagCache.Get(cacheKey); ok {
+			request.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	response, err := p.doWithRetry(request, target)
+	if err != nil {
+		return response, err
+	}
+
+	if !useCache {
+		return response, nil
+	}
+
+	if response.StatusCode == http.StatusNotModified {
+		if _, payload, ok := p.etagCache.Get(cacheKey); ok {
+			pagination, rateLimit, err := decodeCachedResponse(payload, target)
+			if err != nil {
+				return response, err
+			}
+			response.Pagination = pagination
+			response.RateLimit = rateLimit
+		}
+	} else if response.ETag != "" {
+		if payload, err := encodeCachedResponse(target, response.Pagination, response.RateLimit); err == nil {
+			p.etagCache.Set(cacheKey, response.ETag, payload)
+		}
+	}
+
+	return response, nil
+}
+
+// encodeCachedResponse serializes target's current JSON encoding together
+// with pagination and rateLimit into the payload an ETagCache stores
+// alongside an ETag.
+func encodeCachedResponse(target interface{}, pagination Pagination, rateLimit RateLimit) ([]byte, error) {
+	body, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(cachedResponse{Body: body, Pagination: pagination, RateLimit: rateLimit})
+}
+
+// decodeCachedResponse reverses encodeCachedResponse, decoding the cached
+// body into target and returning the pagination and rate limit that
+// accompanied it.
+func decodeCachedResponse(payload []byte, target interface{}) (Pagination, RateLimit, error) {
+	var cached cachedResponse
+	if err := json.Unmarshal(payload, &cached); err != nil {
+		return Pagination{}, RateLimit{}, err
+	}
+
+	if err := json.Unmarshal(cached.Body, target); err != nil {
+		return Pagination{}, RateLimit{}, err
+	}
+
+	return cached.Pagination, cached.RateLimit, nil
+}
+
+// do issues request through the connection the same way doEx does, but
+// returns only the Pagination, matching the existing non-Ex methods.
+func (p ProjectClient) do(request *http.Request, target interface{}) (Pagination, error) {
+	response, err := p.doEx(request, target)
+	if response == nil {
+		return Pagination{}, err
+	}
+
+	return response.Pagination, err
+}
@@ -0,0 +1,74 @@
+package tracker
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCachedResponseRoundTripsBodyAndMetadata(t *testing.T) {
+	want := Story{ID: 42}
+	pagination := Pagination{Total: 3, Limit: 10, Offset: 0}
+	rateLimit := RateLimit{Limit: 100, Remaining: 42, Reset: time.Unix(1700000000, 0).UTC()}
+
+	payload, err := encodeCachedResponse(want, pagination, rateLimit)
+	if err != nil {
+		t.Fatalf("encodeCachedResponse: %v", err)
+	}
+
+	var got Story
+	gotPagination, gotRateLimit, err := decodeCachedResponse(payload, &got)
+	if err != nil {
+		t.Fatalf("decodeCachedResponse: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("decoded body = %+v, want %+v", got, want)
+	}
+	if gotPagination != pagination {
+		t.Fatalf("decoded pagination = %+v, want %+v", gotPagination, pagination)
+	}
+	if !gotRateLimit.Reset.Equal(rateLimit.Reset) || gotRateLimit.Limit != rateLimit.Limit || gotRateLimit.Remaining != rateLimit.Remaining {
+		t.Fatalf("decoded rate limit = %+v, want %+v", gotRateLimit, rateLimit)
+	}
+}
+
+func TestCachedResponsePreservesPaginationTotalAcrossA304(t *testing.T) {
+	// Regression test for a 304 hit losing Pagination.Total: Tracker isn't
+	// required to repeat X-Tracker-Pagination-Total on a 304, so the
+	// iterators' termination check depends on the cache restoring the
+	// original 200's pagination rather than trusting the 304's own (empty)
+	// metadata.
+	original := Pagination{Total: 3}
+	payload, err := encodeCachedResponse([]Story{{ID: 1}, {ID: 2}, {ID: 3}}, original, RateLimit{})
+	if err != nil {
+		t.Fatalf("encodeCachedResponse: %v", err)
+	}
+
+	var stories []Story
+	pagination, _, err := decodeCachedResponse(payload, &stories)
+	if err != nil {
+		t.Fatalf("decodeCachedResponse: %v", err)
+	}
+
+	if pagination.Total != 3 {
+		t.Fatalf("pagination.Total = %d after cache round-trip, want 3", pagination.Total)
+	}
+	if len(stories) != 3 {
+		t.Fatalf("len(stories) = %d after cache round-trip, want 3", len(stories))
+	}
+}
+
+func TestCacheKeyForDistinguishesMethodAndURL(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "http://example.com/stories", nil)
+	post, _ := http.NewRequest(http.MethodPost, "http://example.com/stories", nil)
+	other, _ := http.NewRequest(http.MethodGet, "http://example.com/iterations", nil)
+
+	if cacheKeyFor(get) == cacheKeyFor(post) {
+		t.Fatal("GET and POST to the same URL must not share a cache key")
+	}
+	if cacheKeyFor(get) == cacheKeyFor(other) {
+		t.Fatal("requests to different URLs must not share a cache key")
+	}
+}
@@ -0,0 +1,162 @@
+package tracker
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether a failed attempt should be retried and, if
+// so, how long to wait before the next one. attempt is 1 on the first
+// retry (i.e. the second overall try). resp is nil when the attempt
+// failed with a transport error rather than an HTTP response.
+type RetryPolicy func(attempt int, resp *http.Response, err error) (retry bool, wait time.Duration)
+
+// RetryOptions configures NewRetryPolicy.
+type RetryOptions struct {
+	// BaseDelay is the backoff delay used for the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps both the exponential backoff delay and the wait
+	// computed from X-Tracker-Ratelimit-Reset.
+	MaxDelay time.Duration
+	// MaxAttempts is the maximum number of attempts, including the
+	// first.
+	MaxAttempts int
+}
+
+// DefaultRetryOptions returns the options backing DefaultRetryPolicy: a
+// 500ms base delay doubling up to a 30s cap, and up to 5 attempts.
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		MaxAttempts: 5,
+	}
+}
+
+// NewRetryPolicy builds a RetryPolicy from opts. It retries 429 Too Many
+// Requests by sleeping until X-Tracker-Ratelimit-Reset, capped at
+// opts.MaxDelay, and retries 5xx responses or transport errors with
+// exponential backoff from opts.BaseDelay (factor 2, full jitter), also
+// capped at opts.MaxDelay. It gives up after opts.MaxAttempts attempts.
+func NewRetryPolicy(opts RetryOptions) RetryPolicy {
+	return func(attempt int, resp *http.Response, err error) (bool, time.Duration) {
+		if attempt >= opts.MaxAttempts {
+			return false, 0
+		}
+
+		if resp == nil {
+			return err != nil, backoff(attempt, opts)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			return true, rateLimitWait(resp, opts.MaxDelay)
+		case resp.StatusCode >= 500:
+			return true, backoff(attempt, opts)
+		default:
+			return false, 0
+		}
+	}
+}
+
+// DefaultRetryPolicy is the RetryPolicy new ProjectClients use unless
+// overridden with WithRetryPolicy.
+var DefaultRetryPolicy = NewRetryPolicy(DefaultRetryOptions())
+
+// NoRetryPolicy disables retries: pass it to WithRetryPolicy to send
+// every request exactly once.
+var NoRetryPolicy RetryPolicy = func(attempt int, resp *http.Response, err error) (bool, time.Duration) {
+	return false, 0
+}
+
+func backoff(attempt int, opts RetryOptions) time.Duration {
+	delay := opts.BaseDelay * time.Duration(uint(1)<<uint(attempt-1))
+	if delay > opts.MaxDelay {
+		delay = opts.MaxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+func rateLimitWait(resp *http.Response, max time.Duration) time.Duration {
+	seconds, err := strconv.ParseInt(resp.Header.Get("X-Tracker-Ratelimit-Reset"), 10, 64)
+	if err != nil || seconds <= 0 {
+		return max
+	}
+
+	wait := time.Until(time.Unix(seconds, 0))
+	if wait < 0 {
+		return 0
+	}
+	if wait > max {
+		return max
+	}
+
+	return wait
+}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// WithRetryPolicy returns a copy of p that uses policy to decide whether
+// idempotent (GET/PUT/DELETE) requests should be retried. Pass
+// NoRetryPolicy to disable retries, or a policy built with
+// NewRetryPolicy to tighten or loosen the defaults.
+func (p ProjectClient) WithRetryPolicy(policy RetryPolicy) ProjectClient {
+	p.retryPolicy = policy
+	return p
+}
+
+func (p ProjectClient) retryPolicyOrDefault() RetryPolicy {
+	if p.retryPolicy != nil {
+		return p.retryPolicy
+	}
+
+	return DefaultRetryPolicy
+}
+
+// doWithRetry issues request through the connection, resending it
+// according to the client's RetryPolicy when the method is idempotent.
+// POST requests are sent exactly once, since Tracker has no way to tell
+// a retry from a duplicate create.
+func (p ProjectClient) doWithRetry(request *http.Request, target interface{}) (*Response, error) {
+	if !idempotentMethods[request.Method] {
+		response, err := p.conn.DoEx(request, target)
+		return &response, err
+	}
+
+	policy := p.retryPolicyOrDefault()
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+
+		response, err := p.conn.DoEx(request, target)
+
+		httpResponse := &http.Response{StatusCode: response.StatusCode, Header: response.Header}
+		if err != nil {
+			httpResponse = nil
+		}
+
+		retry, wait := policy(attempt, httpResponse, err)
+		if !retry {
+			return &response, err
+		}
+
+		select {
+		case <-request.Context().Done():
+			return &response, request.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
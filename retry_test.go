@@ -0,0 +1,123 @@
+package tracker
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBackoffCapsAtMaxDelay(t *testing.T) {
+	opts := RetryOptions{BaseDelay: 500 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay := backoff(attempt, opts)
+		if delay < 0 || delay > opts.MaxDelay {
+			t.Fatalf("attempt %d: backoff returned %s, want in [0, %s]", attempt, delay, opts.MaxDelay)
+		}
+	}
+}
+
+func TestBackoffGrowsExponentiallyBeforeCap(t *testing.T) {
+	opts := RetryOptions{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Hour}
+
+	for attempt := 1; attempt <= 4; attempt++ {
+		want := opts.BaseDelay * time.Duration(uint(1)<<uint(attempt-1))
+		for i := 0; i < 20; i++ {
+			if delay := backoff(attempt, opts); delay > want {
+				t.Fatalf("attempt %d: backoff returned %s, want <= %s", attempt, delay, want)
+			}
+		}
+	}
+}
+
+func TestRateLimitWaitMissingHeaderReturnsMax(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if wait := rateLimitWait(resp, 30*time.Second); wait != 30*time.Second {
+		t.Fatalf("rateLimitWait = %s, want 30s", wait)
+	}
+}
+
+func TestRateLimitWaitPastResetReturnsZero(t *testing.T) {
+	resp := &http.Response{Header: http.Header{
+		"X-Tracker-Ratelimit-Reset": []string{"1"},
+	}}
+	if wait := rateLimitWait(resp, 30*time.Second); wait != 0 {
+		t.Fatalf("rateLimitWait = %s, want 0", wait)
+	}
+}
+
+func TestRateLimitWaitCapsAtMax(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	resp := &http.Response{Header: http.Header{
+		"X-Tracker-Ratelimit-Reset": []string{strconv.FormatInt(reset, 10)},
+	}}
+	if wait := rateLimitWait(resp, 5*time.Second); wait != 5*time.Second {
+		t.Fatalf("rateLimitWait = %s, want capped at 5s", wait)
+	}
+}
+
+func TestNewRetryPolicyStopsAtMaxAttempts(t *testing.T) {
+	policy := NewRetryPolicy(RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+
+	retry, _ := policy(3, &http.Response{StatusCode: 500}, nil)
+	if retry {
+		t.Fatal("policy retried on the final attempt")
+	}
+}
+
+func TestNewRetryPolicyRetriesServerErrors(t *testing.T) {
+	policy := NewRetryPolicy(DefaultRetryOptions())
+
+	retry, _ := policy(1, &http.Response{StatusCode: 503}, nil)
+	if !retry {
+		t.Fatal("policy did not retry a 503")
+	}
+}
+
+func TestNewRetryPolicyDoesNotRetryClientErrors(t *testing.T) {
+	policy := NewRetryPolicy(DefaultRetryOptions())
+
+	retry, _ := policy(1, &http.Response{StatusCode: 404}, nil)
+	if retry {
+		t.Fatal("policy retried a 404")
+	}
+}
+
+func TestNewRetryPolicyUsesRateLimitWaitOn429(t *testing.T) {
+	policy := NewRetryPolicy(RetryOptions{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: 30 * time.Second})
+
+	reset := time.Now().Add(2 * time.Second).Unix()
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"X-Tracker-Ratelimit-Reset": []string{strconv.FormatInt(reset, 10)}},
+	}
+
+	retry, wait := policy(1, resp, nil)
+	if !retry {
+		t.Fatal("policy did not retry a 429")
+	}
+	if wait <= 0 || wait > 2*time.Second {
+		t.Fatalf("wait = %s, want in (0, 2s]", wait)
+	}
+}
+
+func TestNewRetryPolicyRetriesTransportErrors(t *testing.T) {
+	policy := NewRetryPolicy(DefaultRetryOptions())
+
+	retry, wait := policy(1, nil, errors.New("connection reset"))
+	if !retry {
+		t.Fatal("policy did not retry a transport error")
+	}
+	if wait < 0 {
+		t.Fatalf("wait = %s, want >= 0", wait)
+	}
+}
+
+func TestNoRetryPolicyNeverRetries(t *testing.T) {
+	retry, wait := NoRetryPolicy(1, &http.Response{StatusCode: 503}, nil)
+	if retry || wait != 0 {
+		t.Fatalf("NoRetryPolicy returned (%v, %s), want (false, 0)", retry, wait)
+	}
+}
@@ -0,0 +1,186 @@
+// Package webhook receives and dispatches Tracker's V5 activity
+// webhooks, turning the polling-only client into an event-driven one.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Activity kinds Tracker sends in ActivityEvent.Kind.
+const (
+	KindStoryCreate   = "story_create_activity"
+	KindStoryUpdate   = "story_update_activity"
+	KindStoryDelete   = "story_delete_activity"
+	KindCommentCreate = "comment_create_activity"
+	KindBlockerCreate = "blocker_create_activity"
+)
+
+// Change describes a single field change carried by an ActivityEvent.
+type Change struct {
+	Kind           string           `json:"kind"`
+	ChangeType     string           `json:"change_type"`
+	ID             int              `json:"id"`
+	OriginalValues *json.RawMessage `json:"original_values"`
+	NewValues      *json.RawMessage `json:"new_values"`
+}
+
+// Resource identifies one of the Tracker entities an ActivityEvent
+// concerns, such as the story a comment was added to.
+type Resource struct {
+	Kind string `json:"kind"`
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Person identifies the Tracker member that performed an activity.
+type Person struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Initials string `json:"initials"`
+}
+
+// ActivityEvent mirrors the payload of a Tracker V5 activity webhook.
+type ActivityEvent struct {
+	ProjectID        int        `json:"project_id"`
+	Kind             string     `json:"kind"`
+	GUID             string     `json:"guid"`
+	Message          string     `json:"message"`
+	Changes          []Change   `json:"changes"`
+	PrimaryResources []Resource `json:"primary_resources"`
+	PerformedBy      Person     `json:"performed_by"`
+	OccurredAt       time.Time  `json:"occurred_at"`
+}
+
+// Handler is an http.Handler that decodes incoming Tracker activity
+// webhooks and dispatches them to callbacks registered through its
+// fluent On* methods. The zero value, or one built with NewHandler, is
+// ready to use.
+type Handler struct {
+	secret []byte
+
+	onStoryCreate   []func(ActivityEvent)
+	onStoryUpdate   []func(ActivityEvent)
+	onStoryDelete   []func(ActivityEvent)
+	onCommentCreate []func(ActivityEvent)
+	onBlockerCreate []func(ActivityEvent)
+	onAny           []func(ActivityEvent)
+}
+
+// NewHandler returns an empty Handler.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// WithSecret configures secret as the shared HMAC-SHA1 secret used to
+// verify the X-Tracker-Webhook-Signature header on every request. When
+// no secret is configured, signatures are not checked.
+func (h *Handler) WithSecret(secret string) *Handler {
+	h.secret = []byte(secret)
+	return h
+}
+
+// OnStoryCreate registers fn to run for every story_create_activity
+// event.
+func (h *Handler) OnStoryCreate(fn func(ActivityEvent)) *Handler {
+	h.onStoryCreate = append(h.onStoryCreate, fn)
+	return h
+}
+
+// OnStoryUpdate registers fn to run for every story_update_activity
+// event.
+func (h *Handler) OnStoryUpdate(fn func(ActivityEvent)) *Handler {
+	h.onStoryUpdate = append(h.onStoryUpdate, fn)
+	return h
+}
+
+// OnStoryDelete registers fn to run for every story_delete_activity
+// event.
+func (h *Handler) OnStoryDelete(fn func(ActivityEvent)) *Handler {
+	h.onStoryDelete = append(h.onStoryDelete, fn)
+	return h
+}
+
+// OnCommentCreate registers fn to run for every comment_create_activity
+// event.
+func (h *Handler) OnCommentCreate(fn func(ActivityEvent)) *Handler {
+	h.onCommentCreate = append(h.onCommentCreate, fn)
+	return h
+}
+
+// OnBlockerCreate registers fn to run for every blocker_create_activity
+// event.
+func (h *Handler) OnBlockerCreate(fn func(ActivityEvent)) *Handler {
+	h.onBlockerCreate = append(h.onBlockerCreate, fn)
+	return h
+}
+
+// OnAny registers fn to run for every event, regardless of kind, after
+// any kind-specific callbacks have run.
+func (h *Handler) OnAny(fn func(ActivityEvent)) *Handler {
+	h.onAny = append(h.onAny, fn)
+	return h
+}
+
+// ServeHTTP implements http.Handler. It verifies the request's signature
+// when a secret is configured, decodes the body into an ActivityEvent,
+// and dispatches it to the registered callbacks.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(h.secret) > 0 && !h.verify(r, body) {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event ActivityEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.dispatch(event)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) verify(r *http.Request, body []byte) bool {
+	mac := hmac.New(sha1.New, h.secret)
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(r.Header.Get("X-Tracker-Webhook-Signature")))
+}
+
+func (h *Handler) dispatch(event ActivityEvent) {
+	var callbacks []func(ActivityEvent)
+
+	switch event.Kind {
+	case KindStoryCreate:
+		callbacks = h.onStoryCreate
+	case KindStoryUpdate:
+		callbacks = h.onStoryUpdate
+	case KindStoryDelete:
+		callbacks = h.onStoryDelete
+	case KindCommentCreate:
+		callbacks = h.onCommentCreate
+	case KindBlockerCreate:
+		callbacks = h.onBlockerCreate
+	}
+
+	for _, fn := range callbacks {
+		fn(event)
+	}
+
+	for _, fn := range h.onAny {
+		fn(event)
+	}
+}
@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestServeHTTPRejectsBadSignature(t *testing.T) {
+	h := NewHandler().WithSecret("s3cr3t")
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"kind":"story_create_activity"}`))
+	req.Header.Set("X-Tracker-Webhook-Signature", "not-the-right-signature")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestServeHTTPAcceptsGoodSignature(t *testing.T) {
+	body := `{"kind":"story_create_activity"}`
+	secret := "s3cr3t"
+
+	var fired bool
+	h := NewHandler().WithSecret(secret).OnStoryCreate(func(ActivityEvent) {
+		fired = true
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("X-Tracker-Webhook-Signature", sign(secret, body))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if !fired {
+		t.Fatal("OnStoryCreate callback did not run")
+	}
+}
+
+func TestServeHTTPSkipsVerificationWithoutSecret(t *testing.T) {
+	var fired bool
+	h := NewHandler().OnStoryUpdate(func(ActivityEvent) {
+		fired = true
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"kind":"story_update_activity"}`))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if !fired {
+		t.Fatal("OnStoryUpdate callback did not run")
+	}
+}
+
+func TestServeHTTPDispatchesByKindThenOnAny(t *testing.T) {
+	var order []string
+
+	h := NewHandler().
+		OnStoryDelete(func(ActivityEvent) { order = append(order, "story_delete") }).
+		OnCommentCreate(func(ActivityEvent) { order = append(order, "comment_create") }).
+		OnAny(func(ActivityEvent) { order = append(order, "any") })
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"kind":"story_delete_activity"}`))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	if len(order) != 2 || order[0] != "story_delete" || order[1] != "any" {
+		t.Fatalf("dispatch order = %v, want [story_delete any]", order)
+	}
+}
+
+func TestServeHTTPDecodesEventFields(t *testing.T) {
+	var got ActivityEvent
+	h := NewHandler().OnBlockerCreate(func(e ActivityEvent) { got = e })
+
+	body, err := json.Marshal(ActivityEvent{
+		ProjectID: 7,
+		Kind:      KindBlockerCreate,
+		GUID:      "abc-123",
+		PerformedBy: Person{
+			ID:   1,
+			Name: "Ada Lovelace",
+		},
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	if got.ProjectID != 7 || got.GUID != "abc-123" || got.PerformedBy.Name != "Ada Lovelace" {
+		t.Fatalf("decoded event = %+v, want ProjectID=7 GUID=abc-123 PerformedBy.Name=\"Ada Lovelace\"", got)
+	}
+}
+
+func TestServeHTTPRejectsMalformedBody(t *testing.T) {
+	h := NewHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`not json`))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,80 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Webhook is a project's registration for Tracker V5 activity webhooks,
+// delivered to the tracker/webhook package's Handler.
+type Webhook struct {
+	ID        int    `json:"id"`
+	ProjectID int    `json:"project_id"`
+	URL       string `json:"webhook_url"`
+	Version   string `json:"version"`
+}
+
+// RegisterWebhook registers url with Tracker as a V5 activity webhook
+// endpoint for the project.
+func (p ProjectClient) RegisterWebhook(url string) (Webhook, error) {
+	return p.RegisterWebhookContext(context.Background(), url)
+}
+
+func (p ProjectClient) RegisterWebhookContext(ctx context.Context, url string) (Webhook, error) {
+	request, err := p.createRequest("POST", "/webhooks", nil)
+	if err != nil {
+		return Webhook{}, err
+	}
+	request = request.WithContext(ctx)
+
+	buffer := &bytes.Buffer{}
+	json.NewEncoder(buffer).Encode(struct {
+		URL     string `json:"webhook_url"`
+		Version string `json:"version"`
+	}{
+		URL:     url,
+		Version: "v5",
+	})
+
+	p.addJSONBodyReader(request, buffer)
+
+	var webhook Webhook
+	_, err = p.do(request, &webhook)
+	return webhook, err
+}
+
+// ListWebhooks returns every webhook registered for the project.
+func (p ProjectClient) ListWebhooks() ([]Webhook, error) {
+	return p.ListWebhooksContext(context.Background())
+}
+
+func (p ProjectClient) ListWebhooksContext(ctx context.Context) ([]Webhook, error) {
+	request, err := p.createRequest("GET", "/webhooks", nil)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+
+	var webhooks []Webhook
+	_, err = p.do(request, &webhooks)
+	return webhooks, err
+}
+
+// DeleteWebhook removes the webhook registration identified by id.
+func (p ProjectClient) DeleteWebhook(id int) error {
+	return p.DeleteWebhookContext(context.Background(), id)
+}
+
+func (p ProjectClient) DeleteWebhookContext(ctx context.Context, id int) error {
+	url := fmt.Sprintf("/webhooks/%d", id)
+	request, err := p.createRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+	request = request.WithContext(ctx)
+
+	_, err = p.do(request, nil)
+	return err
+}